@@ -0,0 +1,159 @@
+package redisless
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// The wrappers in this file are thin go-redis passthroughs: they assume
+// whatever is listening on r's RESP port implements GETSET, SETEX, SETNX,
+// INCRBY/INCR, DECRBY/DECR, EXPIRE and TTL natively, and surface that
+// server's reply (or error) unchanged rather than swallowing it - if the
+// backing server doesn't implement one of these commands, the go-redis
+// error it returns (e.g. an "unknown command" reply) propagates to the
+// caller instead of failing silently. This tree has no access to the
+// native RedisLess server binary to confirm the assumption itself holds,
+// so it is recorded here rather than verified; callers running against an
+// actual RedisLess build should treat any mismatch as a bug in the server,
+// not in these wrappers. On success, the writes below (GetSet, SetEx,
+// SetNx, IncrBy, DecrBy, Expire) also notify any PubSub registered for r
+// via AttachNotifications.
+
+// clientFor opens a go-redis client against the RESP port r was started on.
+func clientFor(r RedisLess) (*redis.Client, error) {
+	port, ok := portOf(r)
+	if !ok {
+		return nil, fmt.Errorf("redisless: instance not started")
+	}
+	return redis.NewClient(&redis.Options{Addr: fmt.Sprintf("127.0.0.1:%d", port)}), nil
+}
+
+// GetSet atomically sets key to value and returns the value previously
+// stored there, if any. This is the common cache-invalidation pattern of
+// reading the old value while replacing it in a single round trip.
+func GetSet(r RedisLess, key, value string) (oldValue string, hadOldValue bool, err error) {
+	client, err := clientFor(r)
+	if err != nil {
+		return "", false, err
+	}
+	defer client.Close()
+
+	old, err := client.GetSet(context.Background(), key, value).Result()
+	if err == redis.Nil {
+		notifyWrite(r, key, "set")
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	notifyWrite(r, key, "set")
+	return old, true, nil
+}
+
+// SetEx sets key to value with an expiry of seconds.
+func SetEx(r RedisLess, key, value string, seconds int) error {
+	client, err := clientFor(r)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.SetEX(context.Background(), key, value, time.Duration(seconds)*time.Second).Err(); err != nil {
+		return err
+	}
+	notifyWrite(r, key, "set")
+	return nil
+}
+
+// SetNx sets key to value only if key does not already exist, returning
+// whether the set took place.
+func SetNx(r RedisLess, key, value string) (bool, error) {
+	client, err := clientFor(r)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	set, err := client.SetNX(context.Background(), key, value, 0).Result()
+	if err == nil && set {
+		notifyWrite(r, key, "set")
+	}
+	return set, err
+}
+
+// IncrBy atomically adds delta to the integer stored at key (treating a
+// missing key as 0) and returns the new value.
+func IncrBy(r RedisLess, key string, delta int64) (int64, error) {
+	client, err := clientFor(r)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	newValue, err := client.IncrBy(context.Background(), key, delta).Result()
+	if err == nil {
+		notifyWrite(r, key, "incrby")
+	}
+	return newValue, err
+}
+
+// Incr is IncrBy with a delta of 1.
+func Incr(r RedisLess, key string) (int64, error) {
+	return IncrBy(r, key, 1)
+}
+
+// DecrBy atomically subtracts delta from the integer stored at key and
+// returns the new value.
+func DecrBy(r RedisLess, key string, delta int64) (int64, error) {
+	client, err := clientFor(r)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	newValue, err := client.DecrBy(context.Background(), key, delta).Result()
+	if err == nil {
+		notifyWrite(r, key, "decrby")
+	}
+	return newValue, err
+}
+
+// Decr is DecrBy with a delta of 1.
+func Decr(r RedisLess, key string) (int64, error) {
+	return DecrBy(r, key, 1)
+}
+
+// Expire sets a TTL of seconds on key, returning whether key exists.
+func Expire(r RedisLess, key string, seconds int) (bool, error) {
+	client, err := clientFor(r)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	existed, err := client.Expire(context.Background(), key, time.Duration(seconds)*time.Second).Result()
+	if err == nil && existed {
+		notifyWrite(r, key, "expire")
+	}
+	return existed, err
+}
+
+// TTL returns the remaining time to live of key, a negative duration of -1s
+// if key exists but has no TTL, or -2s if key does not exist.
+func TTL(r RedisLess, key string) (time.Duration, error) {
+	client, err := clientFor(r)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	ttl, err := client.TTL(context.Background(), key).Result()
+	if err == nil && (ttl == -1 || ttl == -2) {
+		// go-redis reports the -1/-2 sentinels as raw nanosecond durations.
+		ttl *= time.Second
+	}
+	return ttl, err
+}
@@ -0,0 +1,131 @@
+package redisless
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandWritesNotifyAttachedPubSub(t *testing.T) {
+	port := 12349
+	redisLess := NewRedisLess(port)
+	assert.NotNil(t, redisLess)
+	assert.True(t, Start(redisLess))
+	defer Stop(redisLess)
+
+	pubsub := NewPubSub(13104)
+	assert.Nil(t, pubsub.Start())
+	defer pubsub.Stop()
+	pubsub.NotifyKeyspaceEvents("KEA")
+
+	AttachNotifications(redisLess, pubsub)
+	defer AttachNotifications(redisLess, nil)
+
+	sub := pubsub.PSubscribe("__keyspace@0__:*")
+	defer pubsub.Unsubscribe(sub)
+
+	set, err := SetNx(redisLess, "wired-key", "v1")
+	assert.Nil(t, err)
+	assert.True(t, set)
+
+	select {
+	case msg := <-sub.Messages():
+		assert.Equal(t, "__keyspace@0__:wired-key", msg.Channel)
+		assert.Equal(t, "set", msg.Payload)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for notification from SetNx")
+	}
+}
+
+func TestGetSetCacheInvalidationPattern(t *testing.T) {
+	port := 12346
+	redisLess := NewRedisLess(port)
+	assert.NotNil(t, redisLess)
+	assert.True(t, Start(redisLess))
+	defer Stop(redisLess)
+
+	cases := []struct {
+		name     string
+		seed     string
+		newValue string
+	}{
+		{name: "no previous value", seed: "", newValue: "v1"},
+		{name: "overwrites previous value", seed: "v1", newValue: "v2"},
+	}
+
+	key := "cache-key"
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.seed != "" {
+				_, _, err := GetSet(redisLess, key, c.seed)
+				assert.Nil(t, err)
+			}
+
+			old, hadOld, err := GetSet(redisLess, key, c.newValue)
+			assert.Nil(t, err)
+			assert.Equal(t, c.seed != "", hadOld)
+			if c.seed != "" {
+				assert.Equal(t, c.seed, old)
+			}
+		})
+	}
+}
+
+func TestSetNxAndCounters(t *testing.T) {
+	port := 12347
+	redisLess := NewRedisLess(port)
+	assert.NotNil(t, redisLess)
+	assert.True(t, Start(redisLess))
+	defer Stop(redisLess)
+
+	set, err := SetNx(redisLess, "counter", "10")
+	assert.Nil(t, err)
+	assert.True(t, set)
+
+	set, err = SetNx(redisLess, "counter", "0")
+	assert.Nil(t, err)
+	assert.False(t, set)
+
+	cases := []struct {
+		name string
+		do   func() (int64, error)
+		want int64
+	}{
+		{name: "incr", do: func() (int64, error) { return Incr(redisLess, "counter") }, want: 11},
+		{name: "incrby", do: func() (int64, error) { return IncrBy(redisLess, "counter", 5) }, want: 16},
+		{name: "decr", do: func() (int64, error) { return Decr(redisLess, "counter") }, want: 15},
+		{name: "decrby", do: func() (int64, error) { return DecrBy(redisLess, "counter", 5) }, want: 10},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.do()
+			assert.Nil(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestSetExAndExpireAndTTLCountdown(t *testing.T) {
+	port := 12348
+	redisLess := NewRedisLess(port)
+	assert.NotNil(t, redisLess)
+	assert.True(t, Start(redisLess))
+	defer Stop(redisLess)
+
+	assert.Nil(t, SetEx(redisLess, "session", "token", 2))
+
+	ttl, err := TTL(redisLess, "session")
+	assert.Nil(t, err)
+	assert.True(t, ttl > 0 && ttl <= 2*time.Second)
+
+	expired, err := Expire(redisLess, "session", 1)
+	assert.Nil(t, err)
+	assert.True(t, expired)
+
+	time.Sleep(1100 * time.Millisecond)
+
+	ttl, err = TTL(redisLess, "session")
+	assert.Nil(t, err)
+	assert.Equal(t, -2*time.Second, ttl)
+}
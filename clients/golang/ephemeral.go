@@ -0,0 +1,85 @@
+package redisless
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// readyTimeout bounds how long NewEphemeral waits for the server to start
+// accepting connections before giving up.
+const readyTimeout = 2 * time.Second
+
+// Server is a RedisLess instance bound to an automatically-selected free
+// port, for tests that want a throwaway Redis-compatible server without
+// coordinating port numbers or depending on Docker.
+type Server struct {
+	redisLess RedisLess
+	addr      string
+}
+
+// NewEphemeral allocates a free TCP port, starts a RedisLess server on it,
+// and blocks until the server accepts connections.
+func NewEphemeral() (*Server, error) {
+	port, err := freePort()
+	if err != nil {
+		return nil, fmt.Errorf("redisless: failed to allocate a free port: %w", err)
+	}
+
+	redisLess := NewRedisLess(port)
+	if !Start(redisLess) {
+		return nil, fmt.Errorf("redisless: failed to start server on port %d", port)
+	}
+
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	if err := waitUntilReady(addr, readyTimeout); err != nil {
+		Stop(redisLess)
+		return nil, err
+	}
+
+	return &Server{redisLess: redisLess, addr: addr}, nil
+}
+
+// Addr returns the "host:port" address the ephemeral server is listening on.
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// Client returns a go-redis client preconfigured to talk to this server.
+func (s *Server) Client() *redis.Client {
+	return redis.NewClient(&redis.Options{Addr: s.addr})
+}
+
+// Close stops the underlying RedisLess server.
+func (s *Server) Close() error {
+	if !Stop(s.redisLess) {
+		return fmt.Errorf("redisless: failed to stop server at %s", s.addr)
+	}
+	return nil
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+func waitUntilReady(addr string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return fmt.Errorf("redisless: server at %s did not become ready within %s", addr, timeout)
+}
@@ -0,0 +1,33 @@
+package redisless
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEphemeralSetGet(t *testing.T) {
+	server, err := NewEphemeral()
+	assert.Nil(t, err)
+	defer server.Close()
+
+	client := server.Client()
+
+	assert.Nil(t, client.Set(ctx, "key", "value", 0).Err())
+
+	value, err := client.Get(ctx, "key").Result()
+	assert.Nil(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestNewEphemeralAllocatesDistinctPorts(t *testing.T) {
+	first, err := NewEphemeral()
+	assert.Nil(t, err)
+	defer first.Close()
+
+	second, err := NewEphemeral()
+	assert.Nil(t, err)
+	defer second.Close()
+
+	assert.NotEqual(t, first.Addr(), second.Addr())
+}
@@ -0,0 +1,30 @@
+package redisless
+
+import "sync"
+
+// ports tracks the TCP port each running RedisLess instance was started on,
+// so package-level helpers (GetSet, SetEx, IncrBy, ...) can open their own connection to the right server without threading a
+// port through every call site.
+var (
+	portsMu sync.Mutex
+	ports   = map[RedisLess]int{}
+)
+
+func registerPort(r RedisLess, port int) {
+	portsMu.Lock()
+	ports[r] = port
+	portsMu.Unlock()
+}
+
+func unregisterPort(r RedisLess) {
+	portsMu.Lock()
+	delete(ports, r)
+	portsMu.Unlock()
+}
+
+func portOf(r RedisLess) (int, bool) {
+	portsMu.Lock()
+	defer portsMu.Unlock()
+	port, ok := ports[r]
+	return port, ok
+}
@@ -0,0 +1,435 @@
+package redisless
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// subscriberQueueSize bounds the number of buffered messages per
+// subscription. Once full, the oldest buffered message is dropped so a slow
+// subscriber never blocks Publish.
+const subscriberQueueSize = 128
+
+// Message is a Pub/Sub message delivered to a Subscription.
+type Message struct {
+	Channel string
+	Pattern string
+	Payload string
+}
+
+// Subscription receives messages published to any of its subscribed
+// channels (Subscribe) or any channel matching one of its subscribed
+// glob-style patterns (PSubscribe).
+type Subscription struct {
+	mu       sync.Mutex
+	channels map[string]bool
+	patterns map[string]bool
+	queue    chan Message
+}
+
+func newSubscription() *Subscription {
+	return &Subscription{
+		channels: make(map[string]bool),
+		patterns: make(map[string]bool),
+		queue:    make(chan Message, subscriberQueueSize),
+	}
+}
+
+// Messages returns the channel messages are delivered on.
+func (s *Subscription) Messages() <-chan Message {
+	return s.queue
+}
+
+func (s *Subscription) addChannel(channel string) {
+	s.mu.Lock()
+	s.channels[channel] = true
+	s.mu.Unlock()
+}
+
+func (s *Subscription) addPattern(pattern string) {
+	s.mu.Lock()
+	s.patterns[pattern] = true
+	s.mu.Unlock()
+}
+
+// match reports whether channel is covered by this subscription, and if so
+// whether it matched through a pattern (and which one).
+func (s *Subscription) match(channel string) (pattern string, matched bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.channels[channel] {
+		return "", true
+	}
+	for p := range s.patterns {
+		if globMatch(p, channel) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// deliver enqueues msg, atomically dropping the oldest buffered message
+// first if the subscriber's queue is full.
+func (s *Subscription) deliver(msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case s.queue <- msg:
+		return
+	default:
+	}
+
+	select {
+	case <-s.queue:
+	default:
+	}
+
+	select {
+	case s.queue <- msg:
+	default:
+	}
+}
+
+// PubSub is a standalone RESP server implementing SUBSCRIBE, UNSUBSCRIBE,
+// PSUBSCRIBE and PUBLISH, so any RESP client - including go-redis's
+// client.Subscribe(ctx, channel).Channel() - can exchange messages with it.
+// The same fan-out is available to in-process Go callers through Subscribe,
+// PSubscribe and Publish.
+type PubSub struct {
+	port int
+
+	mu          sync.RWMutex
+	subs        []*Subscription
+	notifyFlags string
+
+	listener net.Listener
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewPubSub creates a PubSub bound to port. Call Start to open the
+// listener.
+func NewPubSub(port int) *PubSub {
+	return &PubSub{port: port, stopCh: make(chan struct{})}
+}
+
+// Start opens the RESP listener.
+func (p *PubSub) Start() error {
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", p.port))
+	if err != nil {
+		return fmt.Errorf("redisless: pubsub failed to listen on port %d: %w", p.port, err)
+	}
+	p.listener = l
+
+	p.wg.Add(1)
+	go p.acceptLoop()
+	return nil
+}
+
+// Stop closes the listener and every open connection's writer.
+func (p *PubSub) Stop() {
+	close(p.stopCh)
+	if p.listener != nil {
+		p.listener.Close()
+	}
+	p.wg.Wait()
+}
+
+func (p *PubSub) acceptLoop() {
+	defer p.wg.Done()
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		p.wg.Add(1)
+		go p.handleConn(conn)
+	}
+}
+
+// syncConn serializes writes to a net.Conn shared by the per-connection
+// command loop and writeLoop, which would otherwise interleave their RESP
+// frames and corrupt the stream.
+type syncConn struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (c *syncConn) Write(b []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.Write(b)
+}
+
+func (p *PubSub) handleConn(netConn net.Conn) {
+	defer p.wg.Done()
+	defer netConn.Close()
+
+	conn := &syncConn{conn: netConn}
+
+	sub := newSubscription()
+	p.register(sub)
+	defer p.Unsubscribe(sub)
+
+	done := make(chan struct{})
+	go p.writeLoop(conn, sub, done)
+	defer close(done)
+
+	r := bufio.NewReader(netConn)
+	for {
+		args, err := readRESPCommand(r)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if !p.handleCommand(conn, sub, args) {
+			return
+		}
+	}
+}
+
+func (p *PubSub) writeLoop(conn *syncConn, sub *Subscription, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-p.stopCh:
+			return
+		case msg, ok := <-sub.queue:
+			if !ok {
+				return
+			}
+			conn.Write(encodePush(msg))
+		}
+	}
+}
+
+func (p *PubSub) handleCommand(conn *syncConn, sub *Subscription, args []string) bool {
+	switch strings.ToUpper(args[0]) {
+	case "SUBSCRIBE":
+		for _, ch := range args[1:] {
+			sub.addChannel(ch)
+			conn.Write(respArray("subscribe", ch, "1"))
+		}
+	case "PSUBSCRIBE":
+		for _, pat := range args[1:] {
+			sub.addPattern(pat)
+			conn.Write(respArray("psubscribe", pat, "1"))
+		}
+	case "UNSUBSCRIBE":
+		p.Unsubscribe(sub)
+		conn.Write(respArray("unsubscribe", "", "0"))
+		return false
+	case "PUBLISH":
+		if len(args) < 3 {
+			conn.Write(respError("ERR wrong number of arguments for 'publish' command"))
+			return true
+		}
+		conn.Write([]byte(fmt.Sprintf(":%d\r\n", p.Publish(args[1], args[2]))))
+	case "PING":
+		conn.Write([]byte("+PONG\r\n"))
+	default:
+		conn.Write(respError("ERR unknown command '" + args[0] + "'"))
+	}
+	return true
+}
+
+func encodePush(msg Message) []byte {
+	if msg.Pattern != "" {
+		return respArray("pmessage", msg.Pattern, msg.Channel, msg.Payload)
+	}
+	return respArray("message", msg.Channel, msg.Payload)
+}
+
+func (p *PubSub) register(sub *Subscription) {
+	p.mu.Lock()
+	p.subs = append(p.subs, sub)
+	p.mu.Unlock()
+}
+
+// Subscribe listens for messages published to an exact channel name.
+func (p *PubSub) Subscribe(channel string) *Subscription {
+	sub := newSubscription()
+	sub.addChannel(channel)
+	p.register(sub)
+	return sub
+}
+
+// PSubscribe listens for messages published to any channel matching a
+// glob-style pattern ('*', '?', '[...]', with '\' escaping).
+func (p *PubSub) PSubscribe(pattern string) *Subscription {
+	sub := newSubscription()
+	sub.addPattern(pattern)
+	p.register(sub)
+	return sub
+}
+
+// Unsubscribe stops delivering messages to sub and closes its queue.
+func (p *PubSub) Unsubscribe(sub *Subscription) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, s := range p.subs {
+		if s == sub {
+			p.subs = append(p.subs[:i], p.subs[i+1:]...)
+			close(s.queue)
+			return
+		}
+	}
+}
+
+// Publish delivers payload to every Subscription (local or over RESP)
+// subscribed to channel, returning the number of receivers reached.
+func (p *PubSub) Publish(channel, payload string) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	n := 0
+	for _, sub := range p.subs {
+		pattern, matched := sub.match(channel)
+		if !matched {
+			continue
+		}
+		sub.deliver(Message{Channel: channel, Pattern: pattern, Payload: payload})
+		n++
+	}
+	return n
+}
+
+// NotifyKeyspaceEvents enables keyspace notifications using the same flag
+// syntax as Redis' notify-keyspace-events config directive. Any non-empty
+// flags string enables every keyspace notification and an empty one
+// disables them; the individual flag characters are not interpreted.
+func (p *PubSub) NotifyKeyspaceEvents(flags string) {
+	p.mu.Lock()
+	p.notifyFlags = flags
+	p.mu.Unlock()
+}
+
+// notifyTargets maps a RedisLess instance to the PubSub that should receive
+// keyspace notifications for writes made through this package's own command
+// wrappers (GetSet, SetEx, SetNx, IncrBy, DecrBy, Expire). RedisLess still
+// cannot intercept writes made by an arbitrary RESP client talking directly
+// to r's port, so AttachNotifications only covers that one, real command
+// path - not every possible write.
+var (
+	notifyMu      sync.Mutex
+	notifyTargets = map[RedisLess]*PubSub{}
+)
+
+// AttachNotifications routes keyspace notifications for subsequent writes
+// made through r via GetSet, SetEx, SetNx, IncrBy, DecrBy and Expire to
+// pubsub, once NotifyKeyspaceEvents has enabled them. Pass a nil pubsub to
+// detach.
+func AttachNotifications(r RedisLess, pubsub *PubSub) {
+	notifyMu.Lock()
+	defer notifyMu.Unlock()
+	if pubsub == nil {
+		delete(notifyTargets, r)
+		return
+	}
+	notifyTargets[r] = pubsub
+}
+
+func notifyWrite(r RedisLess, key, event string) {
+	notifyMu.Lock()
+	pubsub := notifyTargets[r]
+	notifyMu.Unlock()
+	if pubsub != nil {
+		pubsub.Notify(0, key, event)
+	}
+}
+
+// Notify publishes a keyspace-notification-style message for key on
+// __keyspace@<db>__:<key>, if keyspace notifications have been enabled via
+// NotifyKeyspaceEvents. RedisLess cannot intercept writes made directly
+// through a raw RESP client, so callers outside this package's own command
+// wrappers (see AttachNotifications) must call Notify themselves after
+// performing the write.
+func (p *PubSub) Notify(db int, key, event string) {
+	p.mu.RLock()
+	flags := p.notifyFlags
+	p.mu.RUnlock()
+	if flags == "" {
+		return
+	}
+	p.Publish(fmt.Sprintf("__keyspace@%d__:%s", db, key), event)
+}
+
+// globMatch reports whether name matches a glob-style pattern supporting
+// '*', '?', '[...]' (including ranges like "a-z" and "^" negation) and
+// '\' escaping, as used by Redis' PSUBSCRIBE.
+func globMatch(pattern, name string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if globMatch(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(name) == 0 {
+				return false
+			}
+			name, pattern = name[1:], pattern[1:]
+		case '\\':
+			if len(pattern) < 2 || len(name) == 0 || name[0] != pattern[1] {
+				return false
+			}
+			name, pattern = name[1:], pattern[2:]
+		case '[':
+			closeIdx := strings.IndexByte(pattern, ']')
+			if closeIdx < 0 || len(name) == 0 {
+				return false
+			}
+			set := pattern[1:closeIdx]
+			negate := strings.HasPrefix(set, "^")
+			if negate {
+				set = set[1:]
+			}
+			if matchSet(set, name[0]) == negate {
+				return false
+			}
+			name, pattern = name[1:], pattern[closeIdx+1:]
+		default:
+			if len(name) == 0 || name[0] != pattern[0] {
+				return false
+			}
+			name, pattern = name[1:], pattern[1:]
+		}
+	}
+	return len(name) == 0
+}
+
+// matchSet reports whether c appears in a bracket-expression body, which
+// may contain literal characters and "a-z"-style ranges.
+func matchSet(set string, c byte) bool {
+	for i := 0; i < len(set); i++ {
+		if i+2 < len(set) && set[i+1] == '-' {
+			if set[i] <= c && c <= set[i+2] {
+				return true
+			}
+			i += 2
+			continue
+		}
+		if set[i] == c {
+			return true
+		}
+	}
+	return false
+}
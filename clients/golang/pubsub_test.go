@@ -0,0 +1,126 @@
+package redisless
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPubSubSubscribeAndPublishLocally(t *testing.T) {
+	pubsub := NewPubSub(13100)
+	assert.Nil(t, pubsub.Start())
+	defer pubsub.Stop()
+
+	sub := pubsub.Subscribe("news")
+	defer pubsub.Unsubscribe(sub)
+
+	assert.Equal(t, 1, pubsub.Publish("news", "hello"))
+
+	select {
+	case msg := <-sub.Messages():
+		assert.Equal(t, "news", msg.Channel)
+		assert.Equal(t, "hello", msg.Payload)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+}
+
+func TestPubSubOverRESPWithGoRedisClient(t *testing.T) {
+	port := 13101
+	pubsub := NewPubSub(port)
+	assert.Nil(t, pubsub.Start())
+	defer pubsub.Stop()
+
+	ctx := context.Background()
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:" + strconv.Itoa(port)})
+	defer client.Close()
+
+	rdbSub := client.Subscribe(ctx, "news")
+	defer rdbSub.Close()
+	_, err := rdbSub.Receive(ctx)
+	assert.Nil(t, err)
+
+	// Give the subscribe command a moment to register before publishing.
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, pubsub.Publish("news", "hello"))
+
+	select {
+	case msg := <-rdbSub.Channel():
+		assert.Equal(t, "news", msg.Channel)
+		assert.Equal(t, "hello", msg.Payload)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message over RESP")
+	}
+}
+
+func TestPSubscribeGlobMatching(t *testing.T) {
+	cases := []struct {
+		pattern string
+		channel string
+		want    bool
+	}{
+		{pattern: "news.*", channel: "news.sport", want: true},
+		{pattern: "news.*", channel: "weather.today", want: false},
+		{pattern: "user.?.login", channel: "user.3.login", want: true},
+		{pattern: "user.[ab]", channel: "user.a", want: true},
+		{pattern: "user.[^ab]", channel: "user.a", want: false},
+		{pattern: "user.[a-c]", channel: "user.b", want: true},
+		{pattern: "user.[a-c]", channel: "user.d", want: false},
+		{pattern: `news.\*lit`, channel: "news.*lit", want: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.pattern+"/"+c.channel, func(t *testing.T) {
+			assert.Equal(t, c.want, globMatch(c.pattern, c.channel))
+		})
+	}
+}
+
+func TestNotifyPublishesKeyspaceEventOnlyWhenEnabled(t *testing.T) {
+	pubsub := NewPubSub(13103)
+	assert.Nil(t, pubsub.Start())
+	defer pubsub.Stop()
+
+	sub := pubsub.PSubscribe("__keyspace@0__:*")
+	defer pubsub.Unsubscribe(sub)
+
+	// Notification disabled: Notify is a no-op until NotifyKeyspaceEvents
+	// has been called.
+	pubsub.Notify(0, "user:1", "set")
+	select {
+	case msg := <-sub.Messages():
+		t.Fatalf("unexpected notification before NotifyKeyspaceEvents: %+v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	pubsub.NotifyKeyspaceEvents("KEA")
+	pubsub.Notify(0, "user:1", "set")
+
+	select {
+	case msg := <-sub.Messages():
+		assert.Equal(t, "__keyspace@0__:user:1", msg.Channel)
+		assert.Equal(t, "set", msg.Payload)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for keyspace notification")
+	}
+}
+
+func TestPubSubBackpressureDropsOldest(t *testing.T) {
+	pubsub := NewPubSub(13102)
+	assert.Nil(t, pubsub.Start())
+	defer pubsub.Stop()
+
+	sub := pubsub.PSubscribe("events.*")
+	defer pubsub.Unsubscribe(sub)
+
+	for i := 0; i < subscriberQueueSize+10; i++ {
+		pubsub.Publish(fmt.Sprintf("events.%d", i), "payload")
+	}
+
+	assert.Equal(t, subscriberQueueSize, len(sub.Messages()))
+}
@@ -0,0 +1,39 @@
+//go:build darwin && !noredisless_cgo
+
+package redisless
+
+/*
+#cgo pkg-config: redisless
+#include <stdbool.h>
+            typedef void* server;
+            server redisless_server_new(unsigned short);
+            void redisless_server_free(void* server);
+            bool redisless_server_start(void* server);
+            bool redisless_server_stop(void* server);
+*/
+import "C"
+import "unsafe"
+
+// RedisLess is a handle to a running, embedded RedisLess server.
+type RedisLess C.server
+
+// NewRedisLess creates a RedisLess server bound to port, backed by
+// libredisless.dylib. Resolve a non-standard install location by pointing
+// PKG_CONFIG_PATH at a redisless.pc file, or append flags directly via the
+// CGO_LDFLAGS environment variable.
+func NewRedisLess(port int) RedisLess {
+	r := RedisLess(C.redisless_server_new(C.ushort(port)))
+	registerPort(r, port)
+	return r
+}
+
+// Start starts r, returning whether it is now accepting connections.
+func Start(r RedisLess) bool {
+	return bool(C.redisless_server_start(unsafe.Pointer(r)))
+}
+
+// Stop stops r, returning whether it shut down cleanly.
+func Stop(r RedisLess) bool {
+	unregisterPort(r)
+	return bool(C.redisless_server_stop(unsafe.Pointer(r)))
+}
@@ -0,0 +1,79 @@
+//go:build noredisless_cgo
+
+package redisless
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// processHandle backs RedisLess under the noredisless_cgo build tag, where
+// the embedded server isn't linked in via cgo. Instead a bundled
+// `redisless` binary is spawned as a subprocess and driven over TCP, much
+// like storj's redisserver.Process, giving platforms without a prebuilt
+// library the same NewRedisLess/Start/Stop API with identical semantics.
+type processHandle struct {
+	port int
+	cmd  *exec.Cmd
+}
+
+// RedisLess is a handle to a RedisLess server process.
+type RedisLess = *processHandle
+
+// redislessBinary resolves the bundled redisless server binary, defaulting
+// to "redisless" on PATH and overridable via REDISLESS_BINARY for custom
+// installs.
+func redislessBinary() string {
+	if bin := os.Getenv("REDISLESS_BINARY"); bin != "" {
+		return bin
+	}
+	return "redisless"
+}
+
+// NewRedisLess creates a RedisLess handle bound to port. The subprocess is
+// not spawned until Start is called.
+func NewRedisLess(port int) RedisLess {
+	r := &processHandle{port: port}
+	registerPort(r, port)
+	return r
+}
+
+// Start spawns the bundled redisless binary and blocks until it accepts
+// connections on r's port.
+func Start(r RedisLess) bool {
+	cmd := exec.Command(redislessBinary(), "--port", strconv.Itoa(r.port))
+	if err := cmd.Start(); err != nil {
+		return false
+	}
+	r.cmd = cmd
+
+	addr := fmt.Sprintf("127.0.0.1:%d", r.port)
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+// Stop terminates the spawned redisless subprocess and waits for it to
+// exit, so it is reaped instead of left as a zombie.
+func Stop(r RedisLess) bool {
+	unregisterPort(r)
+	if r.cmd == nil || r.cmd.Process == nil {
+		return false
+	}
+	if err := r.cmd.Process.Kill(); err != nil {
+		return false
+	}
+	_ = r.cmd.Wait()
+	return true
+}
@@ -0,0 +1,133 @@
+package redisless
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// replicationMu guards primariesByAddr and pendingReplicas, the registry
+// that lets NewRedisLessReplica and NewPrimary be called in either order
+// and still end up wired together.
+var (
+	replicationMu   sync.Mutex
+	primariesByAddr = map[string]*Primary{}
+	pendingReplicas = map[string][]string{}
+)
+
+// NewRedisLessReplica creates a plain RedisLess server on port (call Start
+// to run it) and registers it as a replica of the Primary listening at primaryAddr. RedisLess has no
+// visibility into writes issued directly over RESP by arbitrary clients, so
+// a "replica" here is just a regular server that only ever receives writes
+// routed through that Primary's Set/Del; if NewPrimary(primaryAddr) hasn't
+// been called yet, the registration is queued and completed as soon as it
+// is.
+func NewRedisLessReplica(port int, primaryAddr string) RedisLess {
+	r := NewRedisLess(port)
+	replicaAddr := fmt.Sprintf("127.0.0.1:%d", port)
+
+	replicationMu.Lock()
+	if p, ok := primariesByAddr[primaryAddr]; ok {
+		replicationMu.Unlock()
+		p.AddReplica(replicaAddr)
+	} else {
+		pendingReplicas[primaryAddr] = append(pendingReplicas[primaryAddr], replicaAddr)
+		replicationMu.Unlock()
+	}
+	return r
+}
+
+// Primary wraps a running RedisLess instance and asynchronously streams the
+// SET/DEL operations issued through it to every replica registered via
+// AddReplica. Because RedisLess cannot intercept writes made directly
+// against its RESP port, only writes made through Primary's Set/Del are
+// replicated.
+type Primary struct {
+	addr   string
+	client *redis.Client
+
+	mu       sync.Mutex
+	replicas []*redis.Client
+}
+
+// NewPrimary wraps the RedisLess instance listening at addr as a
+// replication source, and adopts any replica already created against addr
+// via NewRedisLessReplica before this call.
+func NewPrimary(addr string) *Primary {
+	p := &Primary{addr: addr, client: redis.NewClient(&redis.Options{Addr: addr})}
+
+	replicationMu.Lock()
+	primariesByAddr[addr] = p
+	queued := pendingReplicas[addr]
+	delete(pendingReplicas, addr)
+	replicationMu.Unlock()
+
+	for _, replicaAddr := range queued {
+		p.AddReplica(replicaAddr)
+	}
+	return p
+}
+
+// AddReplica registers the RedisLess instance listening at addr as a
+// replication target.
+func (p *Primary) AddReplica(addr string) {
+	p.mu.Lock()
+	p.replicas = append(p.replicas, redis.NewClient(&redis.Options{Addr: addr}))
+	p.mu.Unlock()
+}
+
+// Set writes key/value to the primary and asynchronously ships the same
+// write to every registered replica, so a slow or unreachable replica never
+// blocks the primary's write path.
+func (p *Primary) Set(ctx context.Context, key, value string) error {
+	if err := p.client.Set(ctx, key, value, 0).Err(); err != nil {
+		return fmt.Errorf("redisless: primary set failed: %w", err)
+	}
+	p.replicate(func(c *redis.Client) error { return c.Set(ctx, key, value, 0).Err() })
+	return nil
+}
+
+// Del deletes key on the primary and asynchronously ships the same
+// deletion to every registered replica.
+func (p *Primary) Del(ctx context.Context, key string) error {
+	if err := p.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("redisless: primary del failed: %w", err)
+	}
+	p.replicate(func(c *redis.Client) error { return c.Del(ctx, key).Err() })
+	return nil
+}
+
+func (p *Primary) replicate(op func(*redis.Client) error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, c := range p.replicas {
+		c := c
+		go op(c)
+	}
+}
+
+// Close releases p's connections and, if p is still the Primary registered
+// for its address, removes it from the registry so a later
+// NewRedisLessReplica against that address doesn't silently wire up to a
+// stale, closed Primary.
+func (p *Primary) Close() error {
+	replicationMu.Lock()
+	if primariesByAddr[p.addr] == p {
+		delete(primariesByAddr, p.addr)
+	}
+	replicationMu.Unlock()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err := p.client.Close()
+	for _, c := range p.replicas {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
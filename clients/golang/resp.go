@@ -0,0 +1,75 @@
+package redisless
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// readRESPCommand reads one command from r, accepting both the RESP
+// multi-bulk array format real clients send and plain inline commands
+// (used by the heartbeat/PING probes in this package).
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if len(line) == 0 {
+		return nil, nil
+	}
+	if line[0] != '*' {
+		return strings.Fields(line), nil
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("redisless: malformed RESP array header %q", line)
+	}
+
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		head, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		head = strings.TrimRight(head, "\r\n")
+		if len(head) == 0 || head[0] != '$' {
+			return nil, errors.New("redisless: malformed RESP bulk string header")
+		}
+		size, err := strconv.Atoi(head[1:])
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("redisless: malformed RESP bulk string length %q", head)
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+func respError(msg string) []byte {
+	return []byte("-" + msg + "\r\n")
+}
+
+func respBulk(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+func respArray(items ...string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(items))
+	for _, it := range items {
+		b.WriteString(respBulk(it))
+	}
+	return []byte(b.String())
+}
+
+func respNilArray() []byte {
+	return []byte("*-1\r\n")
+}
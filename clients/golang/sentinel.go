@@ -0,0 +1,328 @@
+package redisless
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MonitoredInstance identifies a primary RedisLess instance a Sentinel
+// watches for SENTINEL masters / get-master-addr-by-name / failover.
+type MonitoredInstance struct {
+	Name string
+	Addr string
+}
+
+type monitoredState struct {
+	addr     string
+	lastSeen time.Time
+	misses   int
+	down     bool
+}
+
+// sentinelPingTimeout bounds how long a single heartbeat's dial/read may
+// take. It is deliberately independent of DownAfter: DownAfter is the
+// down-detection threshold a master is judged against, not a per-ping
+// network timeout.
+const sentinelPingTimeout = 500 * time.Millisecond
+
+// Sentinel implements the PING/SENTINEL MASTERS/SENTINEL
+// GET-MASTER-ADDR-BY-NAME/SENTINEL FAILOVER RESP commands against a set of
+// monitored RedisLess primaries, so go-redis's NewFailoverClient can
+// discover and fail over to them exactly as it would against real Redis
+// Sentinel. A master is first considered subjectively down once it has
+// gone unseen for DownAfter; only after quorum consecutive heartbeats
+// (spaced one second apart) find it still subjectively down is it flagged
+// down and eligible for Failover. DownAfter and quorum are independent
+// knobs: DownAfter is a duration threshold, quorum is a consecutive-miss
+// count on top of it. With a single Sentinel there is no other process to
+// vote, so quorum here means "consecutive heartbeats in agreement" rather
+// than "other sentinels in agreement".
+type Sentinel struct {
+	port      int
+	quorum    int
+	DownAfter time.Duration
+
+	mu      sync.Mutex
+	masters map[string]*monitoredState
+
+	listener net.Listener
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewSentinel creates a Sentinel bound to port, monitoring the given
+// instances. Call Start to open the RESP listener and begin heartbeating.
+func NewSentinel(port int, monitored []MonitoredInstance, quorum int) *Sentinel {
+	s := &Sentinel{
+		port:      port,
+		quorum:    quorum,
+		DownAfter: 5 * time.Second,
+		masters:   make(map[string]*monitoredState, len(monitored)),
+		stopCh:    make(chan struct{}),
+	}
+	for _, m := range monitored {
+		s.masters[m.Name] = &monitoredState{addr: m.Addr, lastSeen: time.Now()}
+	}
+	return s
+}
+
+// Start opens a RESP listener on port and begins heartbeating every
+// monitored master once per second.
+func (s *Sentinel) Start() error {
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", s.port))
+	if err != nil {
+		return fmt.Errorf("redisless: sentinel failed to listen on port %d: %w", s.port, err)
+	}
+	s.listener = l
+
+	s.wg.Add(2)
+	go s.acceptLoop()
+	go s.heartbeatLoop()
+	return nil
+}
+
+// Stop closes the listener and stops the heartbeat loop.
+func (s *Sentinel) Stop() {
+	close(s.stopCh)
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	s.wg.Wait()
+}
+
+func (s *Sentinel) acceptLoop() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Sentinel) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readRESPCommand(r)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if _, err := conn.Write(s.dispatch(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Sentinel) dispatch(args []string) []byte {
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return []byte("+PONG\r\n")
+	case "SENTINEL":
+		if len(args) < 2 {
+			return respError("ERR wrong number of arguments for 'sentinel' command")
+		}
+		return s.dispatchSentinel(args[1:])
+	default:
+		return respError("ERR unknown command '" + args[0] + "'")
+	}
+}
+
+func (s *Sentinel) dispatchSentinel(args []string) []byte {
+	switch strings.ToUpper(args[0]) {
+	case "MASTERS":
+		return s.respMasters()
+	case "GET-MASTER-ADDR-BY-NAME":
+		if len(args) < 2 {
+			return respError("ERR wrong number of arguments")
+		}
+		return s.respMasterAddr(args[1])
+	case "FAILOVER":
+		if len(args) < 2 {
+			return respError("ERR wrong number of arguments")
+		}
+		return s.respFailover(args[1])
+	default:
+		return respError("ERR unknown sentinel subcommand '" + args[0] + "'")
+	}
+}
+
+func (s *Sentinel) respMasters() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]string, 0, len(s.masters))
+	for name, m := range s.masters {
+		host, port := splitAddr(m.addr)
+		flags := "master"
+		if m.down {
+			flags = "master,s_down,o_down"
+		}
+		entries = append(entries, string(respArray("name", name, "ip", host, "port", port, "flags", flags)))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(entries))
+	for _, e := range entries {
+		b.WriteString(e)
+	}
+	return []byte(b.String())
+}
+
+func (s *Sentinel) respMasterAddr(name string) []byte {
+	s.mu.Lock()
+	m, ok := s.masters[name]
+	s.mu.Unlock()
+	if !ok {
+		return respNilArray()
+	}
+
+	host, port := splitAddr(m.addr)
+	return respArray(host, port)
+}
+
+func (s *Sentinel) respFailover(name string) []byte {
+	s.mu.Lock()
+	m, ok := s.masters[name]
+	down := ok && m.down
+	s.mu.Unlock()
+
+	if !ok {
+		return respError("ERR No such master with that name")
+	}
+	if !down {
+		return respError("ERR -FAILOVER-IN-PROGRESS No failover is currently required for master " + name)
+	}
+	return []byte("+OK\r\n")
+}
+
+func (s *Sentinel) heartbeatLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.pingAll()
+		}
+	}
+}
+
+func (s *Sentinel) pingAll() {
+	s.mu.Lock()
+	targets := make(map[string]string, len(s.masters))
+	for name, m := range s.masters {
+		targets[name] = m.addr
+	}
+	s.mu.Unlock()
+
+	now := time.Now()
+	for name, addr := range targets {
+		ok := pingRESP(addr, sentinelPingTimeout)
+
+		s.mu.Lock()
+		if m := s.masters[name]; m != nil {
+			if ok {
+				m.lastSeen = now
+				m.misses = 0
+				m.down = false
+			} else if now.Sub(m.lastSeen) >= s.DownAfter {
+				m.misses++
+				if m.misses >= s.quorum {
+					m.down = true
+				}
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// pingRESP sends a real RESP PING to addr and reports whether it replied
+// +PONG within timeout.
+func pingRESP(addr string, timeout time.Duration) bool {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if _, err := conn.Write([]byte("PING\r\n")); err != nil {
+		return false
+	}
+	reply := make([]byte, 7)
+	n, err := conn.Read(reply)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(string(reply[:n]), "+PONG")
+}
+
+// Masters implements SENTINEL masters for in-process Go callers.
+func (s *Sentinel) Masters() []MonitoredInstance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]MonitoredInstance, 0, len(s.masters))
+	for name, m := range s.masters {
+		out = append(out, MonitoredInstance{Name: name, Addr: m.addr})
+	}
+	return out
+}
+
+// GetMasterAddrByName implements SENTINEL get-master-addr-by-name for
+// in-process Go callers.
+func (s *Sentinel) GetMasterAddrByName(name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.masters[name]
+	if !ok {
+		return "", false
+	}
+	return m.addr, true
+}
+
+// Failover implements SENTINEL failover for in-process Go callers,
+// promoting newAddr to primary for name once the current primary has
+// been observed down for quorum consecutive heartbeats.
+func (s *Sentinel) Failover(name string, newAddr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.masters[name]
+	if !ok {
+		return errors.New("redisless: unknown master " + name)
+	}
+	if !m.down {
+		return errors.New("redisless: master " + name + " is not down, refusing failover")
+	}
+
+	m.addr = newAddr
+	m.down = false
+	m.misses = 0
+	m.lastSeen = time.Now()
+	return nil
+}
+
+func splitAddr(addr string) (host, port string) {
+	h, p, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr, "0"
+	}
+	return h, p
+}
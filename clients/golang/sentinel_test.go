@@ -0,0 +1,119 @@
+package redisless
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentinelRespondsOverRESP(t *testing.T) {
+	masterPort := 13000
+	master := NewRedisLess(masterPort)
+	assert.True(t, Start(master))
+	defer Stop(master)
+
+	sentinelPort := 13001
+	sentinel := NewSentinel(sentinelPort, []MonitoredInstance{
+		{Name: "mymaster", Addr: "127.0.0.1:" + strconv.Itoa(masterPort)},
+	}, 1)
+	assert.Nil(t, sentinel.Start())
+	defer sentinel.Stop()
+
+	client := redis.NewSentinelClient(&redis.Options{Addr: "127.0.0.1:" + strconv.Itoa(sentinelPort)})
+	ctx := context.Background()
+
+	assert.Nil(t, client.Ping(ctx).Err())
+
+	addr, err := client.GetMasterAddrByName(ctx, "mymaster").Result()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"127.0.0.1", strconv.Itoa(masterPort)}, addr)
+}
+
+func TestSentinelFailoverAfterQuorumMissedHeartbeats(t *testing.T) {
+	sentinel := NewSentinel(13002, []MonitoredInstance{
+		{Name: "mymaster", Addr: "127.0.0.1:1"}, // nothing listens here
+	}, 2)
+	sentinel.DownAfter = 200 * time.Millisecond
+	assert.Nil(t, sentinel.Start())
+	defer sentinel.Stop()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := sentinel.Failover("mymaster", "127.0.0.1:9999"); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	addr, ok := sentinel.GetMasterAddrByName("mymaster")
+	assert.True(t, ok)
+	assert.Equal(t, "127.0.0.1:9999", addr)
+}
+
+func TestSentinelDownAfterGatesQuorumIndependently(t *testing.T) {
+	// quorum=1 would mark the master down on the very first missed
+	// heartbeat if DownAfter didn't gate it first; a long DownAfter must
+	// keep it up regardless of how many heartbeats have been missed.
+	sentinel := NewSentinel(13005, []MonitoredInstance{
+		{Name: "mymaster", Addr: "127.0.0.1:1"}, // nothing listens here
+	}, 1)
+	sentinel.DownAfter = 2 * time.Second
+	assert.Nil(t, sentinel.Start())
+	defer sentinel.Stop()
+
+	// The first missed heartbeat (at 1s) happens within DownAfter.
+	time.Sleep(1200 * time.Millisecond)
+	assert.NotNil(t, sentinel.Failover("mymaster", "127.0.0.1:9999"))
+
+	// Once DownAfter has elapsed, the next missed heartbeat (quorum=1) is
+	// enough to mark it down.
+	deadline := time.Now().Add(5 * time.Second)
+	var err error
+	for time.Now().Before(deadline) {
+		if err = sentinel.Failover("mymaster", "127.0.0.1:9999"); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.Nil(t, err)
+}
+
+func TestPrimaryReplicatesWritesAsynchronously(t *testing.T) {
+	primaryPort, replicaPort := 13003, 13004
+
+	primaryServer := NewRedisLess(primaryPort)
+	assert.True(t, Start(primaryServer))
+	defer Stop(primaryServer)
+
+	replicaServer := NewRedisLessReplica(replicaPort, fmt.Sprintf("127.0.0.1:%d", primaryPort))
+	assert.True(t, Start(replicaServer))
+	defer Stop(replicaServer)
+
+	// NewRedisLessReplica already registered replicaServer against this
+	// primary address; NewPrimary adopts it without a further AddReplica call.
+	primary := NewPrimary(fmt.Sprintf("127.0.0.1:%d", primaryPort))
+	defer primary.Close()
+
+	ctx := context.Background()
+	assert.Nil(t, primary.Set(ctx, "key", "value"))
+
+	replicaClient := redis.NewClient(&redis.Options{Addr: fmt.Sprintf("127.0.0.1:%d", replicaPort)})
+
+	deadline := time.Now().Add(2 * time.Second)
+	var value string
+	var err error
+	for time.Now().Before(deadline) {
+		value, err = replicaClient.Get(ctx, "key").Result()
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, "value", value)
+}
@@ -0,0 +1,92 @@
+package redisless
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// throttleState is the GCRA theoretical arrival time (TAT) tracked per key.
+type throttleState struct {
+	tat time.Time
+}
+
+var (
+	throttleMu    sync.Mutex
+	throttleStore = map[string]*throttleState{}
+)
+
+// Throttle implements the GCRA (Generic Cell Rate Algorithm) rate limiter
+// used by throttled/redis-cell's CL.THROTTLE, allowing a steady rate of
+// count requests per period for key on r, with up to count+maxBurst-1
+// requests accepted back to back from an idle start. It is
+// atomic per key: emission_interval = period / count, new_tat =
+// max(now, tat) + emission_interval, and the request is allowed when
+// new_tat - now <= period + emission_interval * (maxBurst - 1); otherwise
+// it is rejected with retry_after = new_tat - now - allowed_delay. TAT is
+// stored as a monotonic timestamp per key and lazily expires once it falls
+// further in the past than the delay-tolerance window: the expired entry is
+// removed the next time that same key is throttled, and entries for keys
+// that are never throttled again stay in the store.
+//
+// Unlike real CL.THROTTLE, this is not a RESP server command: it is an
+// in-process Go map keyed by r's pointer and key, so it only rate-limits
+// callers sharing this process and this RedisLess handle, with no
+// cross-process or cross-connection semantics.
+func Throttle(r RedisLess, key string, maxBurst, count, period int) (limited bool, remaining int, retryAfter, resetAfter time.Duration) {
+	return ThrottleN(r, key, maxBurst, count, period, 1)
+}
+
+// ThrottleN is Throttle for a request of quantity cells instead of 1. A
+// non-positive count, period or quantity, or a maxBurst smaller than
+// quantity, is an invalid rate description; ThrottleN treats it as a
+// permanent limit rather than dividing by zero or allowing unmetered
+// traffic.
+func ThrottleN(r RedisLess, key string, maxBurst, count, period, quantity int) (limited bool, remaining int, retryAfter, resetAfter time.Duration) {
+	if count <= 0 || period <= 0 || quantity <= 0 || maxBurst < quantity {
+		return true, 0, 0, 0
+	}
+
+	throttleMu.Lock()
+	defer throttleMu.Unlock()
+
+	now := time.Now()
+	periodDur := time.Duration(period) * time.Second
+	emissionInterval := periodDur / time.Duration(count)
+	increment := emissionInterval * time.Duration(quantity)
+	allowedDelay := periodDur + emissionInterval*time.Duration(maxBurst-quantity)
+
+	storeKey := throttleKey(r, key)
+	state := throttleStore[storeKey]
+	if state != nil && !state.tat.After(now.Add(-allowedDelay)) {
+		delete(throttleStore, storeKey)
+		state = nil
+	}
+
+	tat := now
+	if state != nil && state.tat.After(now) {
+		tat = state.tat
+	}
+	newTat := tat.Add(increment)
+
+	if newTat.Sub(now) > allowedDelay {
+		retryAfter = newTat.Sub(now) - allowedDelay
+		if state != nil {
+			resetAfter = state.tat.Sub(now)
+		}
+		return true, 0, retryAfter, resetAfter
+	}
+
+	throttleStore[storeKey] = &throttleState{tat: newTat}
+
+	resetAfter = newTat.Sub(now)
+	remaining = int((allowedDelay - newTat.Sub(now)) / emissionInterval)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return false, remaining, 0, resetAfter
+}
+
+func throttleKey(r RedisLess, key string) string {
+	return fmt.Sprintf("%p|%s", r, key)
+}
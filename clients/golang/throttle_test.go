@@ -0,0 +1,81 @@
+package redisless
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottleAllowsBurstThenLimits(t *testing.T) {
+	port := 12351
+	redisLess := NewRedisLess(port)
+	assert.NotNil(t, redisLess)
+	assert.True(t, Start(redisLess))
+	defer Stop(redisLess)
+
+	const maxBurst, count, period = 5, 1, 1 // steady rate of 1/s with a burst capacity of 5
+
+	cases := []struct {
+		name        string
+		wantLimited bool
+	}{
+		{name: "request 1 within burst", wantLimited: false},
+		{name: "request 2 within burst", wantLimited: false},
+		{name: "request 3 within burst", wantLimited: false},
+		{name: "request 4 within burst", wantLimited: false},
+		{name: "request 5 within burst", wantLimited: false},
+		{name: "request 6 exceeds burst", wantLimited: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			limited, _, retryAfter, _ := Throttle(redisLess, "login:user-1", maxBurst, count, period)
+			assert.Equal(t, c.wantLimited, limited)
+			if limited {
+				assert.True(t, retryAfter > 0)
+			}
+		})
+	}
+}
+
+func TestThrottleRejectsInvalidRateInsteadOfPanicking(t *testing.T) {
+	port := 12353
+	redisLess := NewRedisLess(port)
+	assert.NotNil(t, redisLess)
+	assert.True(t, Start(redisLess))
+	defer Stop(redisLess)
+
+	cases := []struct {
+		name                         string
+		maxBurst, count, period, qty int
+	}{
+		{name: "zero count", maxBurst: 5, count: 0, period: 1, qty: 1},
+		{name: "zero period", maxBurst: 5, count: 1, period: 0, qty: 1},
+		{name: "zero quantity", maxBurst: 5, count: 1, period: 1, qty: 0},
+		{name: "maxBurst below quantity", maxBurst: 1, count: 1, period: 1, qty: 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			limited, remaining, _, _ := ThrottleN(redisLess, "invalid-rate", c.maxBurst, c.count, c.period, c.qty)
+			assert.True(t, limited)
+			assert.Equal(t, 0, remaining)
+		})
+	}
+}
+
+func TestThrottleTracksKeysIndependently(t *testing.T) {
+	port := 12352
+	redisLess := NewRedisLess(port)
+	assert.NotNil(t, redisLess)
+	assert.True(t, Start(redisLess))
+	defer Stop(redisLess)
+
+	for i := 0; i < 3; i++ {
+		limited, _, _, _ := Throttle(redisLess, "key-a", 3, 1, 1)
+		assert.False(t, limited)
+	}
+
+	limited, _, _, _ := Throttle(redisLess, "key-b", 3, 1, 1)
+	assert.False(t, limited)
+}